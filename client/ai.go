@@ -0,0 +1,185 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AIService handles AI operations
+type AIService struct {
+	client *Client
+}
+
+// GenerateSchema uses AI to generate a schema from natural language
+func (a *AIService) GenerateSchema(ctx context.Context, description string) (string, error) {
+	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/schema", map[string]string{
+		"description": description,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Schema string `json:"schema"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.Schema, nil
+}
+
+// Embed generates embeddings for the given text (for RAG applications)
+func (a *AIService) Embed(ctx context.Context, text string) ([]float32, error) {
+	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/embed", map[string]string{
+		"text": text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embedding []float32 `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result.Embedding, nil
+}
+
+// Message is one turn of a chat conversation. Role is one of "system",
+// "user", "assistant", or "tool". ToolCallID identifies which tool
+// invocation a "tool" message is replying to.
+type Message struct {
+	Role       string `json:"role"`
+	Content    string `json:"content,omitempty"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// ToolSpec describes a tool the model may call, in a provider-agnostic
+// shape. The server translates it into each provider's native tool
+// format.
+type ToolSpec struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	JSONSchema  map[string]interface{} `json:"json_schema"`
+}
+
+// ToolCall is an invocation of a tool the model decided to call.
+// Arguments is the tool's arguments encoded as a JSON object string.
+type ToolCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatRequest is a provider-agnostic chat completion request.
+type ChatRequest struct {
+	Model       string     `json:"model"`
+	Messages    []Message  `json:"messages"`
+	Tools       []ToolSpec `json:"tools,omitempty"`
+	Temperature float32    `json:"temperature,omitempty"`
+	MaxTokens   int        `json:"max_tokens,omitempty"`
+}
+
+// ChatResponse is a complete, non-streamed chat completion.
+type ChatResponse struct {
+	Message      Message    `json:"message"`
+	ToolCalls    []ToolCall `json:"tool_calls,omitempty"`
+	FinishReason string     `json:"finish_reason,omitempty"`
+}
+
+// ChatDelta is one incremental piece of a streamed chat completion. A
+// ToolCall delta carries a full tool invocation rather than incremental
+// argument fragments, so callers can execute it as soon as it arrives. Err
+// is set on the final delta if the stream was cut short by a read error
+// (e.g. a frame that exceeded the scanner's buffer) rather than ending
+// normally via "[DONE]" or context cancellation.
+type ChatDelta struct {
+	Content      string    `json:"content,omitempty"`
+	ToolCall     *ToolCall `json:"tool_call,omitempty"`
+	FinishReason string    `json:"finish_reason,omitempty"`
+	Err          error     `json:"-"`
+}
+
+// Chat performs a non-streamed chat completion.
+func (a *AIService) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/chat", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ChatStream performs a streamed chat completion, returning a channel of
+// deltas read from a server-sent-events stream of "data: {json}\n\n"
+// frames. The channel is closed when the server sends "[DONE]", the
+// stream ends, or ctx is canceled.
+func (a *AIService) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatDelta, error) {
+	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/chat/stream", req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ai chat stream: unexpected status %d", resp.StatusCode)
+	}
+
+	deltas := make(chan ChatDelta)
+
+	go func() {
+		defer resp.Body.Close()
+		defer close(deltas)
+
+		// A tool call's arguments can push a single SSE frame well past
+		// bufio.Scanner's 64 KiB default, so give it room to grow.
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			payload, ok := strings.CutPrefix(line, "data: ")
+			if !ok {
+				continue
+			}
+			if payload == "[DONE]" {
+				return
+			}
+
+			var delta ChatDelta
+			if err := json.Unmarshal([]byte(payload), &delta); err != nil {
+				continue
+			}
+
+			select {
+			case deltas <- delta:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case deltas <- ChatDelta{Err: fmt.Errorf("ai chat stream: reading response: %w", err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return deltas, nil
+}
@@ -13,15 +13,22 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gobackhomee/sdk/config"
+	"github.com/gobackhomee/sdk/ens"
+	"github.com/gobackhomee/sdk/fleet"
+	"github.com/gobackhomee/sdk/oidc"
 	"github.com/gobackhomee/sdk/types"
 )
 
 // Client is the Gobackhomee SDK client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	apiKey     string
-	walletAuth *WalletAuth
+	baseURL     string
+	httpClient  *http.Client
+	apiKey      string
+	walletAuth  *WalletAuth
+	ethRPCURL   string
+	ensRegistry string
+	fleetConfig *config.FleetConfig
 }
 
 // WalletAuth holds Web3 authentication credentials
@@ -54,6 +61,24 @@ func WithTimeout(d time.Duration) Option {
 	}
 }
 
+// WithEthereumRPC configures the Ethereum JSON-RPC endpoint used for
+// client-side ENS resolution. If registryAddress is empty, the ENS
+// mainnet registry is used.
+func WithEthereumRPC(rpcURL, registryAddress string) Option {
+	return func(c *Client) {
+		c.ethRPCURL = rpcURL
+		c.ensRegistry = registryAddress
+	}
+}
+
+// WithFleetConfig configures the fleet master this client's Fleet()
+// service talks to.
+func WithFleetConfig(cfg config.FleetConfig) Option {
+	return func(c *Client) {
+		c.fleetConfig = &cfg
+	}
+}
+
 // New creates a new Gobackhomee client
 func New(baseURL string, opts ...Option) *Client {
 	c := &Client{
@@ -70,6 +95,19 @@ func New(baseURL string, opts ...Option) *Client {
 	return c
 }
 
+// ensClient builds an ENS resolver client from the configured Ethereum
+// RPC endpoint, or returns an error if none was set via WithEthereumRPC.
+func (c *Client) ensClient() (*ens.Client, error) {
+	if c.ethRPCURL == "" {
+		return nil, fmt.Errorf("ens resolution requires an Ethereum RPC endpoint; configure one with client.WithEthereumRPC")
+	}
+	registry := c.ensRegistry
+	if registry == "" {
+		registry = ens.MainnetRegistry
+	}
+	return ens.NewClient(c.ethRPCURL, registry), nil
+}
+
 // Auth returns the authentication service
 func (c *Client) Auth() *AuthService {
 	return &AuthService{client: c}
@@ -85,6 +123,31 @@ func (c *Client) AI() *AIService {
 	return &AIService{client: c}
 }
 
+// Deployments returns the deployments service
+func (c *Client) Deployments() *DeploymentsService {
+	return &DeploymentsService{client: c}
+}
+
+// Fleet returns a fleet orchestration client for the master configured
+// with WithFleetConfig. It signs node heartbeats with the wallet
+// configured via WithWalletAuth, if any.
+func (c *Client) Fleet() *fleet.Client {
+	var masterEndpoint string
+	if c.fleetConfig != nil {
+		masterEndpoint = c.fleetConfig.MasterEndpoint
+	}
+
+	var opts []fleet.Option
+	if c.walletAuth != nil {
+		opts = append(opts, fleet.WithSigner(&fleet.Signer{
+			WalletAddress: c.walletAuth.WalletAddress,
+			SignMessage:   c.walletAuth.SignMessage,
+		}))
+	}
+
+	return fleet.NewClient(masterEndpoint, opts...)
+}
+
 // doRequest performs an HTTP request with authentication
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var bodyReader io.Reader
@@ -110,6 +173,26 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	return c.httpClient.Do(req)
 }
 
+// doStreamRequest performs an authenticated HTTP request with a raw,
+// non-JSON body, for endpoints like chunked upload that stream arbitrary
+// bytes rather than a JSON payload.
+func (c *Client) doStreamRequest(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	return c.httpClient.Do(req)
+}
+
 // AuthService handles authentication operations (Web3-native)
 type AuthService struct {
 	client *Client
@@ -134,6 +217,32 @@ func (a *AuthService) SignInWithEthereum(ctx context.Context, message, signature
 	return &identity, nil
 }
 
+// IssueIDToken mints a standard OIDC ID token for an authenticated
+// identity, so downstream systems that only speak OIDC (Kubernetes/Istio
+// ingress, cloud IAM) can consume wallet auth without special-casing it.
+// The returned token is verified against the server's JWKS before being
+// handed back, so callers never trust an unverified JWT.
+func (a *AuthService) IssueIDToken(ctx context.Context, identity *types.Identity, audience string) (*oidc.IDToken, error) {
+	resp, err := a.client.doRequest(ctx, "POST", "/api/auth/id-token", map[string]string{
+		"subject":  identity.ID,
+		"audience": audience,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	verifier := oidc.NewVerifier(a.client.baseURL+"/api/auth/.well-known/jwks.json", a.client.baseURL, audience)
+	return verifier.Verify(ctx, result.Token)
+}
+
 // ProjectsService handles project operations
 type ProjectsService struct {
 	client *Client
@@ -173,47 +282,57 @@ func (p *ProjectsService) List(ctx context.Context) ([]types.Project, error) {
 	return projects, nil
 }
 
-// AIService handles AI operations
-type AIService struct {
-	client *Client
-}
-
-// GenerateSchema uses AI to generate a schema from natural language
-func (a *AIService) GenerateSchema(ctx context.Context, description string) (string, error) {
-	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/schema", map[string]string{
-		"description": description,
+// SetENSDomain binds an ENS name (e.g. "myapp.eth") to a project so that
+// future deployments can be resolved through it.
+func (p *ProjectsService) SetENSDomain(ctx context.Context, projectID, ensName string) error {
+	resp, err := p.client.doRequest(ctx, "POST", fmt.Sprintf("/api/projects/%s/ens", projectID), map[string]string{
+		"ens_name": ensName,
 	})
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
-	var result struct {
-		Schema string `json:"schema"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+	return nil
+}
+
+// ResolveENS resolves an ENS name directly against the configured
+// Ethereum RPC endpoint, decodes its contenthash record, and matches the
+// resulting CID against a deployment's Merkle root.
+func (p *ProjectsService) ResolveENS(ctx context.Context, ensName string) (*types.Deployment, error) {
+	resolver, err := p.client.ensClient()
+	if err != nil {
+		return nil, err
 	}
 
-	return result.Schema, nil
-}
+	protocol, cid, err := resolver.Resolve(ctx, ensName)
+	if err != nil {
+		return nil, err
+	}
+	if protocol != "ipfs" {
+		return nil, fmt.Errorf("ens: %q resolves to an unsupported %s contenthash", ensName, protocol)
+	}
 
-// Embed generates embeddings for the given text (for RAG applications)
-func (a *AIService) Embed(ctx context.Context, text string) ([]float32, error) {
-	resp, err := a.client.doRequest(ctx, "POST", "/api/ai/embed", map[string]string{
-		"text": text,
-	})
+	resp, err := p.client.doRequest(ctx, "GET", fmt.Sprintf("/api/deployments/by-root/%s", cid), nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var result struct {
-		Embedding []float32 `json:"embedding"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var deployment types.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
 		return nil, err
 	}
 
-	return result.Embedding, nil
+	return &deployment, nil
+}
+
+// ReverseResolveENS returns the primary ENS name for a wallet address, so
+// callers can use it to auto-populate a types.Identity's DisplayName.
+func (p *ProjectsService) ReverseResolveENS(ctx context.Context, walletAddress string) (string, error) {
+	resolver, err := p.client.ensClient()
+	if err != nil {
+		return "", err
+	}
+	return resolver.ReverseResolve(ctx, walletAddress)
 }
@@ -0,0 +1,169 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gobackhomee/sdk/merkle"
+	"github.com/gobackhomee/sdk/types"
+)
+
+// DefaultUploadChunkSize is used by callers that don't need to tune the
+// chunk size for CreateUpload/UploadChunk.
+const DefaultUploadChunkSize = 8 * 1024 * 1024 // 8 MiB
+
+// DeploymentsService handles deployment operations
+type DeploymentsService struct {
+	client *Client
+}
+
+// GetProof fetches a Merkle inclusion proof for a single asset within a
+// deployment, so callers can verify content pulled from an untrusted CDN
+// against the deployment's signed Merkle root.
+func (d *DeploymentsService) GetProof(ctx context.Context, deploymentID, path string) (*merkle.Proof, error) {
+	resp, err := d.client.doRequest(ctx, "GET", fmt.Sprintf("/api/deployments/%s/proof?path=%s", url.PathEscape(deploymentID), url.QueryEscape(path)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var proof merkle.Proof
+	if err := json.NewDecoder(resp.Body).Decode(&proof); err != nil {
+		return nil, err
+	}
+
+	return &proof, nil
+}
+
+// Upload tracks a resumable deployment upload in progress.
+type Upload struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Offset    int64  `json:"offset"`
+}
+
+// FileEntry describes one uploaded asset for the finalize manifest. SHA384
+// is the hex-encoded digest computed while streaming the file to the
+// server, and is what an SRI manifest is derived from.
+type FileEntry struct {
+	Path        string `json:"path"`
+	Size        int64  `json:"size"`
+	SHA384      string `json:"sha384"`
+	ContentType string `json:"content_type"`
+}
+
+// CreateUpload starts a new resumable upload for a project.
+func (d *DeploymentsService) CreateUpload(ctx context.Context, projectID string) (*Upload, error) {
+	resp, err := d.client.doRequest(ctx, "POST", fmt.Sprintf("/api/projects/%s/uploads", projectID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var upload Upload
+	if err := json.NewDecoder(resp.Body).Decode(&upload); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// ResumeOffset returns the last offset the server has committed for an
+// in-progress upload, so a caller can resume after a network error
+// without resending already-uploaded bytes.
+func (d *DeploymentsService) ResumeOffset(ctx context.Context, uploadID string) (int64, error) {
+	resp, err := d.client.doStreamRequest(ctx, http.MethodHead, fmt.Sprintf("/api/uploads/%s", uploadID), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	offset, err := strconv.ParseInt(resp.Header.Get("X-Upload-Offset"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse X-Upload-Offset header: %w", err)
+	}
+
+	return offset, nil
+}
+
+// UploadChunk sends the bytes read from r as the chunk starting at offset,
+// and returns the offset the server expects the next chunk to start at.
+func (d *DeploymentsService) UploadChunk(ctx context.Context, uploadID string, offset int64, r io.Reader) (int64, error) {
+	headers := map[string]string{
+		"Content-Type":    "application/octet-stream",
+		"X-Upload-Offset": strconv.FormatInt(offset, 10),
+	}
+
+	resp, err := d.client.doStreamRequest(ctx, http.MethodPut, fmt.Sprintf("/api/uploads/%s/chunks", uploadID), r, headers)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		NextOffset int64 `json:"next_offset"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+
+	return result.NextOffset, nil
+}
+
+// Finalize submits the completed file manifest for an upload. The server
+// cross-checks each entry's SHA384 against what it received before
+// flipping the resulting Deployment's Status from "building" to "ready".
+func (d *DeploymentsService) Finalize(ctx context.Context, uploadID string, manifest []FileEntry) (*types.Deployment, error) {
+	resp, err := d.client.doRequest(ctx, "POST", fmt.Sprintf("/api/uploads/%s/finalize", uploadID), map[string]interface{}{
+		"manifest": manifest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var deployment types.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// HashFileSHA384 streams r through SHA-384 without buffering the whole
+// file in memory, returning its hex-encoded digest and byte count for use
+// in a FileEntry.
+func HashFileSHA384(r io.Reader) (digest string, size int64, err error) {
+	h := sha512.New384()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// SRIManifest converts a finalize manifest into Subresource Integrity
+// strings ("sha384-<base64>") keyed by path, suitable for serving
+// alongside the deployed site.
+func SRIManifest(entries []FileEntry) (map[string]string, error) {
+	manifest := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		digest, err := hex.DecodeString(entry.SHA384)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sha384 digest for %q: %w", entry.Path, err)
+		}
+		manifest[entry.Path] = "sha384-" + base64.StdEncoding.EncodeToString(digest)
+	}
+	return manifest, nil
+}
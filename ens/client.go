@@ -0,0 +1,201 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package ens
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MainnetRegistry is the canonical ENS registry contract address on
+// Ethereum mainnet.
+const MainnetRegistry = "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e"
+
+// Client resolves ENS names against a configured Ethereum JSON-RPC
+// endpoint and registry contract.
+type Client struct {
+	rpcURL     string
+	registry   string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client that talks to rpcURL and resolves names
+// through the registry contract at registryAddress.
+func NewClient(rpcURL, registryAddress string) *Client {
+	return &Client{
+		rpcURL:     rpcURL,
+		registry:   registryAddress,
+		httpClient: &http.Client{},
+	}
+}
+
+// Resolve looks up the contenthash record for an ENS name (e.g.
+// "myapp.eth") and decodes it per EIP-1577.
+func (c *Client) Resolve(ctx context.Context, name string) (protocol, cid string, err error) {
+	node := Namehash(name)
+
+	resolver, err := c.resolverFor(ctx, node)
+	if err != nil {
+		return "", "", fmt.Errorf("ens: resolving %q: %w", name, err)
+	}
+
+	raw, err := c.call(ctx, resolver, "contenthash(bytes32)", node)
+	if err != nil {
+		return "", "", fmt.Errorf("ens: fetching contenthash for %q: %w", name, err)
+	}
+
+	data, err := decodeDynamicBytes(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("ens: decoding contenthash for %q: %w", name, err)
+	}
+	if len(data) == 0 {
+		return "", "", fmt.Errorf("ens: %q has no contenthash record set", name)
+	}
+
+	return DecodeContentHash(data)
+}
+
+// ReverseResolve returns the primary ENS name for a wallet address, i.e.
+// the name record set on "<address>.addr.reverse". It does not check that
+// the returned name's forward record resolves back to address.
+func (c *Client) ReverseResolve(ctx context.Context, address string) (string, error) {
+	reverseName := strings.TrimPrefix(strings.ToLower(address), "0x") + ".addr.reverse"
+	node := Namehash(reverseName)
+
+	resolver, err := c.resolverFor(ctx, node)
+	if err != nil {
+		return "", fmt.Errorf("ens: reverse resolving %q: %w", address, err)
+	}
+
+	raw, err := c.call(ctx, resolver, "name(bytes32)", node)
+	if err != nil {
+		return "", fmt.Errorf("ens: fetching reverse name for %q: %w", address, err)
+	}
+
+	data, err := decodeDynamicBytes(raw)
+	if err != nil {
+		return "", fmt.Errorf("ens: decoding reverse name for %q: %w", address, err)
+	}
+
+	return string(data), nil
+}
+
+// resolverFor calls the registry's resolver(bytes32) function to find the
+// resolver contract responsible for node.
+func (c *Client) resolverFor(ctx context.Context, node [32]byte) (string, error) {
+	raw, err := c.call(ctx, c.registry, "resolver(bytes32)", node)
+	if err != nil {
+		return "", err
+	}
+	addr := decodeAddress(raw)
+	if addr == "0x0000000000000000000000000000000000000000" {
+		return "", fmt.Errorf("no resolver is set for this node")
+	}
+	return addr, nil
+}
+
+// call ABI-encodes a single-bytes32-argument function call, performs an
+// eth_call against to, and returns the raw ABI-encoded result.
+func (c *Client) call(ctx context.Context, to, signature string, arg [32]byte) ([]byte, error) {
+	selectorHash := Keccak256([]byte(signature))
+	data := "0x" + hex.EncodeToString(selectorHash[:4]) + hex.EncodeToString(arg[:])
+
+	req := rpcRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_call",
+		Params: []interface{}{
+			map[string]string{"to": to, "data": data},
+			"latest",
+		},
+		ID: 1,
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("rpc error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(rpcResp.Result, "0x"))
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type rpcResponse struct {
+	Result string    `json:"result"`
+	Error  *rpcError `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// decodeAddress reads a Solidity address from a 32-byte, left-zero-padded
+// ABI word.
+func decodeAddress(word []byte) string {
+	if len(word) < 32 {
+		return "0x0000000000000000000000000000000000000000"
+	}
+	return "0x" + hex.EncodeToString(word[12:32])
+}
+
+// decodeDynamicBytes reads a dynamically-sized `bytes`/`string` ABI
+// return value: a 32-byte offset word, a 32-byte length word at that
+// offset, followed by the data itself.
+func decodeDynamicBytes(result []byte) ([]byte, error) {
+	if len(result) < 64 {
+		return nil, fmt.Errorf("abi: result too short to contain a dynamic value")
+	}
+
+	offset := beUint64(result[24:32])
+	if int(offset)+32 > len(result) {
+		return nil, fmt.Errorf("abi: dynamic value offset out of range")
+	}
+
+	length := beUint64(result[offset+24 : offset+32])
+	start := offset + 32
+	if int(start+length) > len(result) {
+		return nil, fmt.Errorf("abi: dynamic value length out of range")
+	}
+
+	return result[start : start+length], nil
+}
+
+func beUint64(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
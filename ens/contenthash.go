@@ -0,0 +1,55 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package ens
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+)
+
+// Multicodec namespace codes used by EIP-1577 contenthash records.
+const (
+	codecIPFSNamespace  = 0xe3
+	codecIPNSNamespace  = 0xe5
+	codecSwarmNamespace = 0xe4
+)
+
+var contentHashBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// DecodeContentHash decodes an EIP-1577 contenthash record: a varint
+// multicodec namespace code (ipfs-ns, ipns-ns or swarm-ns) followed by the
+// raw bytes of the underlying CID. It returns the namespace's protocol
+// name and the CID re-encoded as a lowercase, multibase-prefixed base32
+// string, matching how IPFS tooling displays CIDv1.
+func DecodeContentHash(data []byte) (protocol, cid string, err error) {
+	code, n := binary.Uvarint(data)
+	if n <= 0 {
+		return "", "", fmt.Errorf("ens: contenthash is missing its multicodec prefix")
+	}
+
+	switch code {
+	case codecIPFSNamespace:
+		protocol = "ipfs"
+	case codecIPNSNamespace:
+		protocol = "ipns"
+	case codecSwarmNamespace:
+		protocol = "swarm"
+	default:
+		return "", "", fmt.Errorf("ens: unsupported contenthash protocol code 0x%x", code)
+	}
+
+	cid = "b" + toLowerBase32(contentHashBase32.EncodeToString(data[n:]))
+	return protocol, cid, nil
+}
+
+func toLowerBase32(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
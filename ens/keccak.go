@@ -0,0 +1,119 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package ens resolves ENS (Ethereum Name Service) names to deployment
+// content hashes, and resolves wallet addresses back to their primary ENS
+// name.
+//
+// Keccak256 below implements the original Keccak-256 hash function (not
+// NIST SHA3-256, which uses different padding) over the Keccak-f[1600]
+// permutation. It is vendored as pure Go so this package can compute ABI
+// function selectors and ENS namehashes without an external dependency.
+package ens
+
+const keccakRate = 136 // 1600-bit state, 512-bit capacity, in bytes
+
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotc = [24]uint{
+	1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14,
+	27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44,
+}
+
+var keccakPiLane = [24]int{
+	10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4,
+	15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1,
+}
+
+func keccakF1600(a *[25]uint64) {
+	var b [25]uint64
+	var c [5]uint64
+	var d [5]uint64
+
+	for round := 0; round < 24; round++ {
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d[x] = c[(x+4)%5] ^ rotl64(c[(x+1)%5], 1)
+		}
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				a[x+5*y] ^= d[x]
+			}
+		}
+
+		current := a[1]
+		for i := 0; i < 24; i++ {
+			lane := keccakPiLane[i]
+			temp := a[lane]
+			a[lane] = rotl64(current, keccakRotc[i])
+			current = temp
+		}
+
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 5; x++ {
+				b[x+5*y] = a[x+5*y]
+			}
+		}
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 5; x++ {
+				a[x+5*y] = b[x+5*y] ^ ((^b[(x+1)%5+5*y]) & b[(x+2)%5+5*y])
+			}
+		}
+
+		a[0] ^= keccakRC[round]
+	}
+}
+
+func rotl64(x uint64, n uint) uint64 {
+	return (x << n) | (x >> (64 - n))
+}
+
+// Keccak256 hashes the concatenation of data using Keccak-256.
+func Keccak256(data ...[]byte) [32]byte {
+	var state [25]uint64
+	buf := make([]byte, 0, keccakRate)
+	for _, d := range data {
+		buf = append(buf, d...)
+	}
+
+	absorb := func(block []byte) {
+		for i := 0; i < keccakRate/8; i++ {
+			var lane uint64
+			for j := 0; j < 8; j++ {
+				lane |= uint64(block[i*8+j]) << (8 * j)
+			}
+			state[i] ^= lane
+		}
+		keccakF1600(&state)
+	}
+
+	for len(buf) >= keccakRate {
+		absorb(buf[:keccakRate])
+		buf = buf[keccakRate:]
+	}
+
+	// Keccak (pre-NIST) multi-rate padding: 0x01 ... 0x80.
+	padded := make([]byte, keccakRate)
+	copy(padded, buf)
+	padded[len(buf)] = 0x01
+	padded[keccakRate-1] |= 0x80
+	absorb(padded)
+
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		lane := state[i]
+		for j := 0; j < 8; j++ {
+			out[i*8+j] = byte(lane >> (8 * j))
+		}
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package ens
+
+import "strings"
+
+// Namehash computes the ENS namehash of name per EIP-137:
+//
+//	namehash("")            = 0x00..00
+//	namehash("label.rest")  = keccak256(namehash("rest") || keccak256("label"))
+func Namehash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := Keccak256([]byte(labels[i]))
+		node = Keccak256(node[:], labelHash[:])
+	}
+	return node
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package fleet is a client for Gobackhomee's multi-node fleet
+// orchestration: registering worker nodes with a master, keeping them
+// alive with signed heartbeats, and assigning deployments to them.
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gobackhomee/sdk/config"
+)
+
+// Node is a fleet worker registered with the master.
+type Node struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	WalletAddress string    `json:"wallet_address,omitempty"`
+	Status        string    `json:"status"`
+	RegisteredAt  time.Time `json:"registered_at"`
+}
+
+// Signer identifies a node by wallet and signs its heartbeats, so the
+// master can validate node identity cryptographically instead of trusting
+// a shared secret.
+type Signer struct {
+	WalletAddress string
+	SignMessage   func(message string) (signature string, err error)
+}
+
+// Client talks to a fleet master endpoint.
+type Client struct {
+	masterEndpoint string
+	httpClient     *http.Client
+	signer         *Signer
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithSigner configures the wallet used to sign node heartbeats.
+func WithSigner(signer *Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// NewClient creates a fleet Client for the given master endpoint.
+func NewClient(masterEndpoint string, opts ...Option) *Client {
+	c := &Client{
+		masterEndpoint: masterEndpoint,
+		httpClient:     &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// RegisterNode registers a worker node with the master using cfg.
+func (c *Client) RegisterNode(ctx context.Context, cfg config.FleetConfig) (*Node, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/nodes", map[string]string{
+		"node_id":   cfg.NodeID,
+		"node_name": cfg.NodeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var node Node
+	if err := json.NewDecoder(resp.Body).Decode(&node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// Heartbeat reports that nodeID is still alive, signing the heartbeat
+// with the configured wallet if one was set via WithSigner. The request
+// is a long-polled HTTP POST: the master may hold the connection open
+// until the next heartbeat is due rather than responding immediately.
+func (c *Client) Heartbeat(ctx context.Context, nodeID string) error {
+	payload := map[string]string{
+		"node_id": nodeID,
+	}
+
+	if c.signer != nil {
+		message := fmt.Sprintf("heartbeat:%s:%d", nodeID, time.Now().Unix())
+		signature, err := c.signer.SignMessage(message)
+		if err != nil {
+			return fmt.Errorf("fleet: signing heartbeat: %w", err)
+		}
+		payload["wallet_address"] = c.signer.WalletAddress
+		payload["message"] = message
+		payload["signature"] = signature
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/nodes/%s/heartbeat", nodeID), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ListNodes returns every node currently registered with the master.
+func (c *Client) ListNodes(ctx context.Context) ([]Node, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/nodes", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var nodes []Node
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// AssignDeployment assigns deploymentID to nodeID.
+func (c *Client) AssignDeployment(ctx context.Context, nodeID, deploymentID string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/nodes/%s/deployments", nodeID), map[string]string{
+		"deployment_id": deploymentID,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.masterEndpoint+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.httpClient.Do(req)
+}
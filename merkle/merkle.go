@@ -0,0 +1,258 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package merkle builds content-addressed Merkle trees over a deployment's
+// file set and produces IPFS-style CIDs and inclusion proofs so that a
+// single asset can be fetched from an untrusted CDN and verified against
+// the signed deployment root.
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// cidMultibasePrefix is the multibase code for lowercase, no-padding base32.
+const cidMultibasePrefix = "b"
+
+// cidVersion and cidCodecRaw identify a CIDv1 over raw bytes.
+const (
+	cidVersion  = 0x01
+	cidCodecRaw = 0x55
+)
+
+// multihash codes, per the multiformats table.
+const (
+	mhCodeSHA256 = 0x12
+	mhLenSHA256  = 0x20
+)
+
+var base32Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// FileLeaf is a single file to be included in a deployment's Merkle tree.
+type FileLeaf struct {
+	Path    string
+	Content []byte
+}
+
+// Sibling is one hash encountered while walking up from a leaf to the root.
+type Sibling struct {
+	// Hash is the hex-encoded SHA-256 hash of the sibling node.
+	Hash string `json:"hash"`
+
+	// Left reports whether the sibling sits to the left of the node on
+	// the path from the leaf, i.e. whether it must be hashed first.
+	Left bool `json:"left"`
+}
+
+// Proof is an inclusion proof for a single asset within a deployment's
+// Merkle tree.
+type Proof struct {
+	// Root is the CID of the deployment's Merkle root.
+	Root string `json:"root"`
+
+	// Path is the asset path the proof covers.
+	Path string `json:"path"`
+
+	// LeafHash is the hex-encoded SHA-256 hash of the leaf.
+	LeafHash string `json:"leaf_hash"`
+
+	// Siblings are the hashes needed to recompute the root, ordered
+	// from the leaf's level up to the root.
+	Siblings []Sibling `json:"siblings"`
+}
+
+// Tree is a Merkle tree built over a deployment's file set, with leaves
+// ordered by path so the same file set always produces the same root.
+type Tree struct {
+	paths  []string
+	levels [][][32]byte
+}
+
+// BuildTree hashes each file into a leaf, sorts leaves by path for
+// determinism, and builds the tree bottom-up, duplicating the last node of
+// an odd level so every level pairs cleanly.
+func BuildTree(files []FileLeaf) (*Tree, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("merkle: cannot build a tree with no files")
+	}
+
+	sorted := make([]FileLeaf, len(files))
+	copy(sorted, files)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	paths := make([]string, len(sorted))
+	leaves := make([][32]byte, len(sorted))
+	for i, f := range sorted {
+		paths[i] = f.Path
+		leaves[i] = leafHash(f.Path, f.Content)
+	}
+
+	levels := [][][32]byte{leaves}
+	for len(levels[len(levels)-1]) > 1 {
+		cur := levels[len(levels)-1]
+		if len(cur)%2 == 1 {
+			cur = append(cur, cur[len(cur)-1])
+		}
+		next := make([][32]byte, len(cur)/2)
+		for i := range next {
+			next[i] = parentHash(cur[2*i], cur[2*i+1])
+		}
+		levels = append(levels, next)
+	}
+
+	return &Tree{paths: paths, levels: levels}, nil
+}
+
+// Root returns the CID of the tree's root, computed once in BuildTree.
+func (t *Tree) Root() string {
+	root := t.levels[len(t.levels)-1][0]
+	return encodeCID(root)
+}
+
+// Proof returns an inclusion proof for the file at path.
+func (t *Tree) Proof(path string) (*Proof, error) {
+	index := -1
+	for i, p := range t.paths {
+		if p == path {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("merkle: path %q is not part of this tree", path)
+	}
+
+	var siblings []Sibling
+	for _, level := range t.levels[:len(t.levels)-1] {
+		levelLen := len(level)
+		pairIndex := index ^ 1
+		if pairIndex >= levelLen {
+			pairIndex = index
+		}
+		siblings = append(siblings, Sibling{
+			Hash: hex.EncodeToString(level[pairIndex][:]),
+			Left: pairIndex < index,
+		})
+		index /= 2
+	}
+
+	return &Proof{
+		Root:     t.Root(),
+		Path:     path,
+		LeafHash: hex.EncodeToString(t.levels[0][indexOf(t.paths, path)][:]),
+		Siblings: siblings,
+	}, nil
+}
+
+// VerifyProof recomputes a Merkle root by hashing sibling pairs bottom-up
+// starting from contentHash, and returns an error if the result does not
+// match proof.Root or if contentHash does not match proof.LeafHash. Callers
+// verifying an asset fetched from an untrusted CDN should derive contentHash
+// from the downloaded bytes with LeafHash(path, content), not read it off
+// the same (untrusted) proof — otherwise the check never actually binds the
+// content to the root.
+func VerifyProof(proof *Proof, contentHash string) error {
+	if contentHash != proof.LeafHash {
+		return fmt.Errorf("merkle: content hash %q does not match leaf hash %q", contentHash, proof.LeafHash)
+	}
+
+	current, err := decodeHash(contentHash)
+	if err != nil {
+		return fmt.Errorf("merkle: invalid content hash: %w", err)
+	}
+
+	for _, sib := range proof.Siblings {
+		sibHash, err := decodeHash(sib.Hash)
+		if err != nil {
+			return fmt.Errorf("merkle: invalid sibling hash: %w", err)
+		}
+		if sib.Left {
+			current = parentHash(sibHash, current)
+		} else {
+			current = parentHash(current, sibHash)
+		}
+	}
+
+	if got := encodeCID(current); got != proof.Root {
+		return fmt.Errorf("merkle: recomputed root %q does not match proof root %q", got, proof.Root)
+	}
+
+	return nil
+}
+
+// LeafHash returns the hex-encoded Merkle leaf hash for a file at path with
+// the given content. Pass the result to VerifyProof to confirm that content
+// pulled from an untrusted CDN really belongs to a deployment's signed root.
+func LeafHash(path string, content []byte) string {
+	h := leafHash(path, content)
+	return hex.EncodeToString(h[:])
+}
+
+func leafHash(path string, content []byte) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(content)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func parentHash(left, right [32]byte) [32]byte {
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(right[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func decodeHash(s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return out, err
+	}
+	if len(b) != len(out) {
+		return out, fmt.Errorf("expected %d bytes, got %d", len(out), len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
+
+// encodeCID wraps a SHA-256 digest in a multihash and encodes it as a
+// CIDv1 string (raw codec, lowercase base32 multibase), similar to IPFS CIDs.
+func encodeCID(digest [32]byte) string {
+	mh := make([]byte, 0, 2+len(digest))
+	mh = append(mh, mhCodeSHA256, mhLenSHA256)
+	mh = append(mh, digest[:]...)
+
+	cid := make([]byte, 0, 2+len(mh))
+	cid = append(cid, cidVersion, cidCodecRaw)
+	cid = append(cid, mh...)
+
+	return cidMultibasePrefix + toLowerBase32(base32Encoding.EncodeToString(cid))
+}
+
+func indexOf(paths []string, path string) int {
+	for i, p := range paths {
+		if p == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func toLowerBase32(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
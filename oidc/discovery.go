@@ -0,0 +1,55 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Discovery is the subset of an OIDC provider's
+// /.well-known/openid-configuration document that this package uses.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	JWKSURI               string `json:"jwks_uri"`
+	AuthorizationEndpoint string `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint         string `json:"token_endpoint,omitempty"`
+}
+
+// FetchDiscovery retrieves and parses baseURL's
+// /.well-known/openid-configuration document.
+func FetchDiscovery(ctx context.Context, baseURL string) (*Discovery, error) {
+	url := strings.TrimSuffix(baseURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: fetching discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var discovery Discovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("oidc: decoding discovery document: %w", err)
+	}
+
+	return &discovery, nil
+}
+
+// NewVerifierFromDiscovery bootstraps a Verifier from just a provider's
+// base URL, fetching its JWKS endpoint via OIDC discovery.
+func NewVerifierFromDiscovery(ctx context.Context, baseURL, expectedAudience string) (*Verifier, error) {
+	discovery, err := FetchDiscovery(ctx, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifier(discovery.JWKSURI, discovery.Issuer, expectedAudience), nil
+}
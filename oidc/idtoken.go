@@ -0,0 +1,34 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package oidc bridges Gobackhomee wallet identities into standard OIDC ID
+// tokens, so downstream systems that only speak OIDC (Kubernetes/Istio
+// ingress, cloud IAM) can consume wallet auth without special-casing it.
+package oidc
+
+import "time"
+
+// IDToken is a verified OIDC ID token minted for a Gobackhomee Identity.
+type IDToken struct {
+	// Subject is the OIDC "sub" claim, typically the identity ID.
+	Subject string
+
+	// Audience is the OIDC "aud" claim the token was issued for.
+	Audience string
+
+	// Issuer is the OIDC "iss" claim.
+	Issuer string
+
+	// WalletAddress carries the identity's wallet address, for
+	// consumers that want Web3-native claims without re-parsing Claims.
+	WalletAddress string
+
+	// Chain is the identity's blockchain, e.g. "ethereum".
+	Chain string
+
+	// Expiry is the OIDC "exp" claim.
+	Expiry time.Time
+
+	// Claims holds the full decoded claim set.
+	Claims map[string]interface{}
+}
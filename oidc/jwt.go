@@ -0,0 +1,102 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// jwtHeader is the JOSE header of a compact-serialized JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// parsedJWT is a JWT split into its three verified-independent parts.
+type parsedJWT struct {
+	header       jwtHeader
+	claims       map[string]interface{}
+	signingInput string
+	signature    []byte
+}
+
+// parseJWT splits and base64url-decodes a compact JWT without verifying
+// its signature.
+func parseJWT(raw string) (*parsedJWT, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("oidc: malformed JWT: expected 3 segments, got %d", len(parts))
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("oidc: decoding JWT header: %w", err)
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("oidc: parsing JWT header: %w", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("oidc: decoding JWT claims: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("oidc: parsing JWT claims: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("oidc: decoding JWT signature: %w", err)
+	}
+
+	return &parsedJWT{
+		header:       header,
+		claims:       claims,
+		signingInput: parts[0] + "." + parts[1],
+		signature:    signature,
+	}, nil
+}
+
+// verifySignature checks the JWT's signature against pub, supporting the
+// RS256 and ES256 algorithms.
+func (j *parsedJWT) verifySignature(pub crypto.PublicKey) error {
+	hashed := sha256.Sum256([]byte(j.signingInput))
+
+	switch j.header.Alg {
+	case "RS256":
+		rsaKey, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("oidc: key for kid %q is not an RSA key", j.header.Kid)
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, hashed[:], j.signature)
+
+	case "ES256":
+		ecKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("oidc: key for kid %q is not an EC key", j.header.Kid)
+		}
+		if len(j.signature) != 64 {
+			return fmt.Errorf("oidc: ES256 signature must be 64 bytes, got %d", len(j.signature))
+		}
+		r := new(big.Int).SetBytes(j.signature[:32])
+		s := new(big.Int).SetBytes(j.signature[32:])
+		if !ecdsa.Verify(ecKey, hashed[:], r, s) {
+			return fmt.Errorf("oidc: ES256 signature verification failed")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("oidc: unsupported signing algorithm %q", j.header.Alg)
+	}
+}
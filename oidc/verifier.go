@@ -0,0 +1,205 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultJWKSRefresh bounds how long a fetched key set is trusted before
+// Verify re-fetches it, so a rotated or revoked key is picked up promptly.
+const defaultJWKSRefresh = 10 * time.Minute
+
+// Verifier validates Gobackhomee-issued ID tokens against a JWKS endpoint,
+// periodically refreshing keys by kid.
+type Verifier struct {
+	jwksURL          string
+	expectedIssuer   string
+	expectedAudience string
+	httpClient       *http.Client
+	refreshInterval  time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]JWK
+	lastFetch time.Time
+}
+
+// NewVerifier creates a Verifier that fetches keys from jwksURL and
+// requires tokens to carry the given issuer and audience.
+func NewVerifier(jwksURL, expectedIssuer, expectedAudience string) *Verifier {
+	return &Verifier{
+		jwksURL:          jwksURL,
+		expectedIssuer:   expectedIssuer,
+		expectedAudience: expectedAudience,
+		httpClient:       &http.Client{},
+		refreshInterval:  defaultJWKSRefresh,
+		keys:             make(map[string]JWK),
+	}
+}
+
+// Verify checks a JWT's signature against the verifier's JWKS, validates
+// iss/aud/exp/nbf, and returns the decoded IDToken.
+func (v *Verifier) Verify(ctx context.Context, rawJWT string) (*IDToken, error) {
+	parsed, err := parseJWT(rawJWT)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := v.keyFor(ctx, parsed.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := parsed.verifySignature(pub); err != nil {
+		return nil, err
+	}
+
+	return v.toIDToken(parsed.claims)
+}
+
+// keyFor returns the JWK for kid, refreshing the cached key set if it is
+// missing or stale.
+func (v *Verifier) keyFor(ctx context.Context, kid string) (JWK, error) {
+	v.mu.Lock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.lastFetch) > v.refreshInterval
+	v.mu.Unlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if err := v.refresh(ctx); err != nil {
+		if ok {
+			// Serve the stale key rather than fail a valid token just
+			// because the JWKS endpoint is temporarily unreachable.
+			return key, nil
+		}
+		return JWK{}, err
+	}
+
+	v.mu.Lock()
+	key, ok = v.keys[kid]
+	v.mu.Unlock()
+	if !ok {
+		return JWK{}, fmt.Errorf("oidc: no key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (v *Verifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("oidc: fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("oidc: decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]JWK, len(set.Keys))
+	for _, k := range set.Keys {
+		keys[k.Kid] = k
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.lastFetch = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+// audienceClaim normalizes the "aud" claim, which per the JWT spec may be
+// either a single string or an array of strings. It returns the claim's
+// original string form for IDToken.Audience (the first entry when aud is
+// an array) alongside the full list of audiences to check membership
+// against.
+func audienceClaim(claims map[string]interface{}) (string, []string) {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud, []string{aud}
+	case []interface{}:
+		audiences := make([]string, 0, len(aud))
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+		var first string
+		if len(audiences) > 0 {
+			first = audiences[0]
+		}
+		return first, audiences
+	default:
+		return "", nil
+	}
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Verifier) toIDToken(claims map[string]interface{}) (*IDToken, error) {
+	iss, _ := claims["iss"].(string)
+	if v.expectedIssuer != "" && iss != v.expectedIssuer {
+		return nil, fmt.Errorf("oidc: unexpected issuer %q", iss)
+	}
+
+	aud, audiences := audienceClaim(claims)
+	if v.expectedAudience != "" && !containsString(audiences, v.expectedAudience) {
+		return nil, fmt.Errorf("oidc: unexpected audience %q", aud)
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("oidc: token is missing an exp claim")
+	}
+	expiry := time.Unix(int64(exp), 0)
+	if time.Now().After(expiry) {
+		return nil, fmt.Errorf("oidc: token expired at %s", expiry)
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if time.Now().Before(time.Unix(int64(nbf), 0)) {
+			return nil, fmt.Errorf("oidc: token is not valid yet")
+		}
+	}
+
+	sub, _ := claims["sub"].(string)
+	walletAddress, _ := claims["wallet_address"].(string)
+	chain, _ := claims["chain"].(string)
+
+	return &IDToken{
+		Subject:       sub,
+		Audience:      aud,
+		Issuer:        iss,
+		WalletAddress: walletAddress,
+		Chain:         chain,
+		Expiry:        expiry,
+		Claims:        claims,
+	}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package rbac enforces role-based access control for fleet operations,
+// using a policy fetched from the fleet master plus the wildcard admin
+// roles and default role configured in config.RBACConfig.
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gobackhomee/sdk/config"
+	"github.com/gobackhomee/sdk/types"
+)
+
+// Rule grants a role permission to perform action on resource. Role,
+// Action, and Resource may each be "*" to match anything.
+type Rule struct {
+	Role     string `json:"role"`
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+}
+
+// Policy is an ordered set of RBAC rules.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadPolicy fetches the RBAC policy document from a fleet master.
+func LoadPolicy(ctx context.Context, masterEndpoint string) (*Policy, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, masterEndpoint+"/rbac/policy", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rbac: fetching policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var policy Policy
+	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return nil, fmt.Errorf("rbac: decoding policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// Enforcer checks whether a user is allowed to perform an action on a
+// resource, given a Policy and the AdminRoles/DefaultRole configured for
+// the fleet.
+type Enforcer struct {
+	policy *Policy
+	cfg    config.RBACConfig
+}
+
+// NewEnforcer creates an Enforcer from a loaded Policy and RBACConfig.
+func NewEnforcer(policy *Policy, cfg config.RBACConfig) *Enforcer {
+	return &Enforcer{policy: policy, cfg: cfg}
+}
+
+// Check returns nil if user may perform action on resource, and an error
+// describing the denial otherwise. RBAC is bypassed entirely if
+// cfg.Enabled is false. A user's roles default to cfg.DefaultRole when
+// they have none, and any role matching cfg.AdminRoles is granted
+// unconditional access.
+func (e *Enforcer) Check(user *types.User, action, resource string) error {
+	if !e.cfg.Enabled {
+		return nil
+	}
+
+	roles := user.Roles
+	if len(roles) == 0 {
+		if e.cfg.DefaultRole == "" {
+			return fmt.Errorf("rbac: user %s has no roles and no default role is configured", user.ID)
+		}
+		roles = []string{e.cfg.DefaultRole}
+	}
+
+	for _, role := range roles {
+		for _, admin := range e.cfg.AdminRoles {
+			if matches(admin, role) {
+				return nil
+			}
+		}
+	}
+
+	for _, role := range roles {
+		for _, rule := range e.policy.Rules {
+			if matches(rule.Role, role) && matches(rule.Action, action) && matches(rule.Resource, resource) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("rbac: user %s is not permitted to %s %s", user.ID, action, resource)
+}
+
+// matches reports whether value satisfies pattern, where pattern may be
+// "*" to match anything.
+func matches(pattern, value string) bool {
+	return pattern == "*" || pattern == value
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is a flat, in-memory Store used as a drop-in fallback when
+// config.DatabaseConfig.Driver isn't "postgres" — primarily for tests.
+// Its HybridSearch uses a simple term-overlap score in place of Postgres
+// full-text rank, since there's no database to compute bm25 against.
+type MemoryStore struct {
+	mu       sync.Mutex
+	items    map[string]*memoryItem
+	embedder Embedder
+}
+
+type memoryItem struct {
+	id        string
+	embedding []float32
+	metadata  map[string]interface{}
+	projectID string
+	text      string
+}
+
+// NewMemoryStore creates an empty MemoryStore. embedder is used by
+// HybridSearch to turn its text argument into a query vector.
+func NewMemoryStore(embedder Embedder) *MemoryStore {
+	return &MemoryStore{
+		items:    make(map[string]*memoryItem),
+		embedder: embedder,
+	}
+}
+
+// Upsert implements Store.
+func (s *MemoryStore) Upsert(ctx context.Context, id string, embedding []float32, metadata map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[id] = &memoryItem{
+		id:        id,
+		embedding: embedding,
+		metadata:  metadata,
+		projectID: stringField(metadata, "project_id"),
+		text:      stringField(metadata, "text"),
+	}
+	return nil
+}
+
+// Search implements Store.
+func (s *MemoryStore) Search(ctx context.Context, query []float32, k int, filter Filter) ([]Match, error) {
+	scored := s.scoredItems(filter, func(item *memoryItem) float32 {
+		return cosineSimilarity(query, item.embedding)
+	})
+	return paginate(scored, k, filter.Cursor), nil
+}
+
+// HybridSearch implements Store.
+func (s *MemoryStore) HybridSearch(ctx context.Context, text string, k int, alpha float32, filter Filter) ([]Match, error) {
+	if s.embedder == nil {
+		return nil, fmt.Errorf("vector: HybridSearch requires an Embedder")
+	}
+
+	queryVec, err := s.embedder(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("vector: embedding query text: %w", err)
+	}
+	queryTerms := termSet(text)
+
+	scored := s.scoredItems(filter, func(item *memoryItem) float32 {
+		vectorScore := cosineSimilarity(queryVec, item.embedding)
+		lexicalScore := termOverlapScore(queryTerms, termSet(item.text))
+		return alpha*vectorScore + (1-alpha)*lexicalScore
+	})
+	return paginate(scored, k, filter.Cursor), nil
+}
+
+func (s *MemoryStore) scoredItems(filter Filter, score func(*memoryItem) float32) []Match {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := make([]Match, 0, len(s.items))
+	for _, item := range s.items {
+		if !matchesFilter(item, filter) {
+			continue
+		}
+		matches = append(matches, Match{
+			ID:       item.id,
+			Score:    score(item),
+			Metadata: item.metadata,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	return matches
+}
+
+func matchesFilter(item *memoryItem, filter Filter) bool {
+	if filter.ProjectID != "" && item.projectID != filter.ProjectID {
+		return false
+	}
+	for k, v := range filter.Metadata {
+		if item.metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// paginate applies cursor-based keyset pagination: cursor is the ID of
+// the last Match seen on the previous page, and results resume after it.
+func paginate(sorted []Match, k int, cursor string) []Match {
+	start := 0
+	if cursor != "" {
+		for i, m := range sorted {
+			if m.ID == cursor {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + k
+	if end > len(sorted) || k <= 0 {
+		end = len(sorted)
+	}
+	if start > end {
+		start = end
+	}
+
+	return sorted[start:end]
+}
+
+func stringField(metadata map[string]interface{}, key string) string {
+	if v, ok := metadata[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func termSet(text string) map[string]bool {
+	terms := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		terms[word] = true
+	}
+	return terms
+}
+
+func termOverlapScore(query, doc map[string]bool) float32 {
+	if len(query) == 0 || len(doc) == 0 {
+		return 0
+	}
+	var overlap int
+	for term := range query {
+		if doc[term] {
+			overlap++
+		}
+	}
+	return float32(overlap) / float32(len(query))
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreSearchAndPagination(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(nil)
+
+	items := []struct {
+		id        string
+		embedding []float32
+	}{
+		{"a", []float32{1, 0, 0}},
+		{"b", []float32{0.9, 0.1, 0}},
+		{"c", []float32{0, 1, 0}},
+		{"d", []float32{0, 0, 1}},
+	}
+	for _, item := range items {
+		if err := store.Upsert(ctx, item.id, item.embedding, nil); err != nil {
+			t.Fatalf("Upsert(%q): %v", item.id, err)
+		}
+	}
+
+	query := []float32{1, 0, 0}
+
+	first, err := store.Search(ctx, query, 2, Filter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(first) != 2 || first[0].ID != "a" || first[1].ID != "b" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+
+	second, err := store.Search(ctx, query, 2, Filter{Cursor: first[len(first)-1].ID})
+	if err != nil {
+		t.Fatalf("Search with cursor: %v", err)
+	}
+	if len(second) != 2 || second[0].ID != "c" || second[1].ID != "d" {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+
+	third, err := store.Search(ctx, query, 2, Filter{Cursor: second[len(second)-1].ID})
+	if err != nil {
+		t.Fatalf("Search past the last page: %v", err)
+	}
+	if len(third) != 0 {
+		t.Fatalf("expected no results past the last page, got %+v", third)
+	}
+}
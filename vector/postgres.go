@@ -0,0 +1,189 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PostgresStore is a Store backed by a pgvector-enabled Postgres table.
+// The table is expected to have the shape:
+//
+//	CREATE TABLE <table> (
+//		id         text PRIMARY KEY,
+//		project_id text NOT NULL DEFAULT '',
+//		embedding  vector NOT NULL,
+//		metadata   jsonb NOT NULL DEFAULT '{}',
+//		content    text NOT NULL DEFAULT '',
+//		search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+//	);
+//
+// PostgresStore takes a *sql.DB rather than opening its own connection,
+// so callers bring whatever pgx/lib-pq driver they've already registered.
+type PostgresStore struct {
+	db       *sql.DB
+	table    string
+	embedder Embedder
+}
+
+// NewPostgresStore creates a PostgresStore over db using table. embedder
+// is used by HybridSearch to turn its text argument into a query vector.
+func NewPostgresStore(db *sql.DB, table string, embedder Embedder) *PostgresStore {
+	return &PostgresStore{db: db, table: table, embedder: embedder}
+}
+
+// Upsert implements Store.
+func (s *PostgresStore) Upsert(ctx context.Context, id string, embedding []float32, metadata map[string]interface{}) error {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("vector: marshaling metadata: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, project_id, embedding, metadata, content)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			project_id = EXCLUDED.project_id,
+			embedding  = EXCLUDED.embedding,
+			metadata   = EXCLUDED.metadata,
+			content    = EXCLUDED.content
+	`, s.table)
+
+	_, err = s.db.ExecContext(ctx, query,
+		id,
+		stringField(metadata, "project_id"),
+		formatVector(embedding),
+		metadataJSON,
+		stringField(metadata, "text"),
+	)
+	if err != nil {
+		return fmt.Errorf("vector: upserting %q: %w", id, err)
+	}
+	return nil
+}
+
+// Search implements Store.
+func (s *PostgresStore) Search(ctx context.Context, query []float32, k int, filter Filter) ([]Match, error) {
+	metadataFilter, err := metadataJSONFilter(filter.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		WITH cursor_row AS (
+			SELECT embedding <=> $1 AS cursor_distance FROM %[1]s WHERE id = $5
+		)
+		SELECT t.id, t.metadata, 1 - (t.embedding <=> $1) AS score
+		FROM %[1]s t LEFT JOIN cursor_row c ON true
+		WHERE ($2 = '' OR t.project_id = $2)
+			AND ($4 = '' OR t.metadata @> $4::jsonb)
+			AND ($5 = '' OR (t.embedding <=> $1, t.id) > (c.cursor_distance, $5))
+		ORDER BY t.embedding <=> $1, t.id
+		LIMIT $3
+	`, s.table)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, formatVector(query), filter.ProjectID, k, metadataFilter, filter.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("vector: searching: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMatches(rows)
+}
+
+// HybridSearch implements Store. It blends pgvector cosine similarity
+// with Postgres full-text rank:
+//
+//	score = alpha*vector_score + (1-alpha)*bm25_score
+func (s *PostgresStore) HybridSearch(ctx context.Context, text string, k int, alpha float32, filter Filter) ([]Match, error) {
+	if s.embedder == nil {
+		return nil, fmt.Errorf("vector: HybridSearch requires an Embedder")
+	}
+
+	queryVec, err := s.embedder(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("vector: embedding query text: %w", err)
+	}
+
+	metadataFilter, err := metadataJSONFilter(filter.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		WITH scored AS (
+			SELECT id, metadata,
+				($4 * (1 - (embedding <=> $1))) +
+				((1 - $4) * ts_rank(search_vector, plainto_tsquery('english', $2))) AS score
+			FROM %[1]s
+			WHERE ($3 = '' OR project_id = $3)
+				AND ($5 = '' OR metadata @> $5::jsonb)
+		),
+		cursor_row AS (
+			SELECT score AS cursor_score FROM scored WHERE id = $7
+		)
+		SELECT s.id, s.metadata, s.score
+		FROM scored s LEFT JOIN cursor_row c ON true
+		WHERE $7 = '' OR s.score < c.cursor_score OR (s.score = c.cursor_score AND s.id > $7)
+		ORDER BY s.score DESC, s.id
+		LIMIT $6
+	`, s.table)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery,
+		formatVector(queryVec), text, filter.ProjectID, alpha, metadataFilter, k, filter.Cursor,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("vector: hybrid searching: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMatches(rows)
+}
+
+func scanMatches(rows *sql.Rows) ([]Match, error) {
+	var matches []Match
+	for rows.Next() {
+		var match Match
+		var metadataJSON []byte
+		if err := rows.Scan(&match.ID, &metadataJSON, &match.Score); err != nil {
+			return nil, fmt.Errorf("vector: scanning row: %w", err)
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &match.Metadata); err != nil {
+				return nil, fmt.Errorf("vector: unmarshaling metadata: %w", err)
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// metadataJSONFilter marshals a Filter's metadata predicate into a JSON
+// object for jsonb containment matching (metadata @> filter), or returns ""
+// if no metadata filter was requested.
+func metadataJSONFilter(metadata map[string]interface{}) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("vector: marshaling metadata filter: %w", err)
+	}
+	return string(b), nil
+}
+
+// formatVector renders embedding in pgvector's text input format, e.g.
+// "[0.1,0.2,0.3]".
+func formatVector(embedding []float32) string {
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
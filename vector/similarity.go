@@ -0,0 +1,26 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+package vector
+
+import "math"
+
+// cosineSimilarity returns the cosine similarity of a and b, or 0 if
+// either vector has zero magnitude or they differ in length.
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
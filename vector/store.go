@@ -0,0 +1,46 @@
+// Copyright (C) 2024 Gobackhomee
+// SPDX-License-Identifier: MIT
+
+// Package vector stores and queries text embeddings for RAG applications,
+// backed by pgvector when config.DatabaseConfig.Driver is "postgres" and
+// falling back to an in-memory flat index otherwise (e.g. in tests).
+package vector
+
+import "context"
+
+// Embedder turns text into an embedding, so Store implementations can
+// accept raw text without depending on how embeddings are produced.
+// Callers typically wire this up to AIService.Embed.
+type Embedder func(ctx context.Context, text string) ([]float32, error)
+
+// Match is a single search result.
+type Match struct {
+	ID       string                 `json:"id"`
+	Score    float32                `json:"score"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Filter scopes a search to a project namespace and, optionally, an exact
+// match on metadata fields. Cursor continues a previous page: pass the ID
+// of the last Match seen to fetch the next page of results.
+type Filter struct {
+	ProjectID string
+	Metadata  map[string]interface{}
+	Cursor    string
+}
+
+// Store upserts and queries embeddings, optionally scoped by ProjectID so
+// multiple tenants can share one table without colliding.
+type Store interface {
+	// Upsert inserts or updates the embedding and metadata for id.
+	Upsert(ctx context.Context, id string, embedding []float32, metadata map[string]interface{}) error
+
+	// Search returns the k nearest matches to query by cosine similarity.
+	Search(ctx context.Context, query []float32, k int, filter Filter) ([]Match, error)
+
+	// HybridSearch blends cosine similarity against the embedding of
+	// text with a full-text search rank, weighted by alpha:
+	//
+	//	score = alpha*vector_score + (1-alpha)*bm25_score
+	HybridSearch(ctx context.Context, text string, k int, alpha float32, filter Filter) ([]Match, error)
+}